@@ -0,0 +1,55 @@
+package msgmux
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/uudashr/msgmux/broker"
+)
+
+// Bind connects every message type registered via [DispatchMux.Handle] to
+// topicFor's derived topic on b, so messages published from another process
+// are decoded with codec and dispatched through [DispatchMux.DispatchContext]
+// like any in-process [DispatchMux.Dispatch] call.
+//
+// Bind must be called after all handlers are registered; types registered
+// afterwards are not subscribed.
+//
+// If subscribing a message type fails partway through, Bind unsubscribes
+// every subscription it already created, disconnects b, and returns the
+// error; it never leaves a partially-bound broker connected.
+func (m *DispatchMux) Bind(ctx context.Context, b broker.Broker, codec broker.Codec, topicFor func(reflect.Type) string) error {
+	if err := b.Connect(ctx); err != nil {
+		return fmt.Errorf("msgmux: connect broker: %w", err)
+	}
+
+	var subs []broker.Subscription
+
+	for msgType := range m.handlers {
+		msgType := msgType
+
+		topic := topicFor(msgType)
+
+		sub, err := b.Subscribe(ctx, topic, func(d broker.Delivery) error {
+			msg := reflect.New(msgType).Interface()
+			if err := codec.Unmarshal(d.Payload, msg); err != nil {
+				return fmt.Errorf("msgmux: decode message for topic %s: %w", topic, err)
+			}
+
+			return m.DispatchContext(ctx, reflect.ValueOf(msg).Elem().Interface())
+		})
+		if err != nil {
+			for _, sub := range subs {
+				sub.Unsubscribe()
+			}
+			b.Disconnect(ctx)
+
+			return fmt.Errorf("msgmux: subscribe topic %s: %w", topic, err)
+		}
+
+		subs = append(subs, sub)
+	}
+
+	return nil
+}
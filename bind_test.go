@@ -0,0 +1,106 @@
+package msgmux_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/uudashr/msgmux"
+	"github.com/uudashr/msgmux/broker"
+)
+
+func TestDispatchMux_Bind(t *testing.T) {
+	type OrderCompleted struct {
+		OrderID string `json:"order_id"`
+	}
+
+	mux := msgmux.NewDispatchMux()
+
+	var got OrderCompleted
+	mux.Handle(func(event OrderCompleted) error {
+		got = event
+		return nil
+	})
+
+	b := broker.NewMemory()
+	var codec broker.JSONCodec
+
+	err := mux.Bind(context.Background(), b, codec, func(t reflect.Type) string {
+		return "events." + t.Name()
+	})
+	require.NoError(t, err)
+
+	err = b.Publish(context.Background(), "events.OrderCompleted", []byte(`{"order_id":"order-123"}`), nil)
+	require.NoError(t, err)
+	require.Equal(t, OrderCompleted{OrderID: "order-123"}, got)
+}
+
+// failingSubscribeBroker fails the failAt'th call to Subscribe (1-indexed)
+// and succeeds on every other, recording Unsubscribe/Disconnect calls so
+// tests can assert on partial-failure cleanup regardless of the order
+// [msgmux.DispatchMux.Bind] happens to iterate handlers in.
+type failingSubscribeBroker struct {
+	failAt int
+
+	calls          int
+	disconnected   bool
+	unsubscribedAt []string
+}
+
+func (b *failingSubscribeBroker) Connect(ctx context.Context) error {
+	return nil
+}
+
+func (b *failingSubscribeBroker) Disconnect(ctx context.Context) error {
+	b.disconnected = true
+	return nil
+}
+
+func (b *failingSubscribeBroker) Publish(ctx context.Context, topic string, payload []byte, header map[string]string) error {
+	return nil
+}
+
+func (b *failingSubscribeBroker) Subscribe(ctx context.Context, topic string, handler func(broker.Delivery) error) (broker.Subscription, error) {
+	b.calls++
+	if b.calls == b.failAt {
+		return nil, errors.New("boom")
+	}
+
+	return fakeSubscription{broker: b, topic: topic}, nil
+}
+
+type fakeSubscription struct {
+	broker *failingSubscribeBroker
+	topic  string
+}
+
+func (s fakeSubscription) Unsubscribe() error {
+	s.broker.unsubscribedAt = append(s.broker.unsubscribedAt, s.topic)
+	return nil
+}
+
+func TestDispatchMux_Bind_rollsBackOnPartialFailure(t *testing.T) {
+	type OrderCompleted struct {
+		OrderID string
+	}
+
+	type OrderCancelled struct {
+		OrderID string
+	}
+
+	mux := msgmux.NewDispatchMux()
+	mux.Handle(func(event OrderCompleted) error { return nil })
+	mux.Handle(func(event OrderCancelled) error { return nil })
+
+	b := &failingSubscribeBroker{failAt: 2}
+	var codec broker.JSONCodec
+
+	err := mux.Bind(context.Background(), b, codec, func(t reflect.Type) string {
+		return "events." + t.Name()
+	})
+	require.Error(t, err)
+	require.Len(t, b.unsubscribedAt, 1)
+	require.True(t, b.disconnected)
+}
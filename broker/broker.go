@@ -0,0 +1,46 @@
+// Package broker defines a minimal abstraction over external message queues
+// (NATS, Redis Streams, ...) so a [msgmux.DispatchMux] can receive messages
+// published from another process, not just from in-process callers of
+// [msgmux.DispatchMux.Dispatch].
+package broker
+
+import "context"
+
+// Delivery is a single message received from a [Broker] subscription.
+type Delivery struct {
+	// Topic the message was received on.
+	Topic string
+
+	// Payload is the raw, still-encoded message body.
+	Payload []byte
+
+	// Header carries transport-level metadata (e.g. content type,
+	// correlation id) alongside Payload.
+	Header map[string]string
+}
+
+// Subscription represents an active subscription created by
+// [Broker.Subscribe]. Unsubscribe stops delivery and releases any resources
+// held by the subscription.
+type Subscription interface {
+	Unsubscribe() error
+}
+
+// Broker is a minimal publish/subscribe abstraction over an external
+// message queue.
+type Broker interface {
+	// Connect establishes the connection to the underlying queue.
+	Connect(ctx context.Context) error
+
+	// Disconnect tears down the connection established by Connect.
+	Disconnect(ctx context.Context) error
+
+	// Publish sends payload to topic, carrying the given header.
+	Publish(ctx context.Context, topic string, payload []byte, header map[string]string) error
+
+	// Subscribe registers handler to be called for every [Delivery]
+	// received on topic. handler returning an error does not unsubscribe;
+	// it is up to the [Broker] implementation to decide how to surface or
+	// retry the failure.
+	Subscribe(ctx context.Context, topic string, handler func(Delivery) error) (Subscription, error)
+}
@@ -0,0 +1,54 @@
+package broker
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// Codec converts a message to and from its wire representation. The msg
+// passed to Unmarshal is a pointer to the concrete message struct, e.g. as
+// produced by reflect.New.
+type Codec interface {
+	Marshal(msg any) ([]byte, error)
+	Unmarshal(data []byte, msg any) error
+}
+
+// JSONCodec marshals and unmarshals messages as JSON.
+type JSONCodec struct{}
+
+// Marshal implements [Codec].
+func (JSONCodec) Marshal(msg any) ([]byte, error) {
+	return json.Marshal(msg)
+}
+
+// Unmarshal implements [Codec].
+func (JSONCodec) Unmarshal(data []byte, msg any) error {
+	return json.Unmarshal(data, msg)
+}
+
+// ProtoCodec marshals and unmarshals messages using the protocol buffers
+// wire format. msg must implement [proto.Message], otherwise Marshal and
+// Unmarshal return an error.
+type ProtoCodec struct{}
+
+// Marshal implements [Codec].
+func (ProtoCodec) Marshal(msg any) ([]byte, error) {
+	pm, ok := msg.(proto.Message)
+	if !ok {
+		return nil, fmt.Errorf("broker: message %T does not implement proto.Message", msg)
+	}
+
+	return proto.Marshal(pm)
+}
+
+// Unmarshal implements [Codec].
+func (ProtoCodec) Unmarshal(data []byte, msg any) error {
+	pm, ok := msg.(proto.Message)
+	if !ok {
+		return fmt.Errorf("broker: message %T does not implement proto.Message", msg)
+	}
+
+	return proto.Unmarshal(data, pm)
+}
@@ -0,0 +1,52 @@
+package broker_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/uudashr/msgmux/broker"
+)
+
+func TestJSONCodec(t *testing.T) {
+	type OrderCompleted struct {
+		OrderID string `json:"order_id"`
+	}
+
+	var codec broker.JSONCodec
+
+	data, err := codec.Marshal(OrderCompleted{OrderID: "order-123"})
+	require.NoError(t, err)
+
+	var got OrderCompleted
+	err = codec.Unmarshal(data, &got)
+	require.NoError(t, err)
+	require.Equal(t, "order-123", got.OrderID)
+}
+
+func TestProtoCodec(t *testing.T) {
+	var codec broker.ProtoCodec
+
+	data, err := codec.Marshal(wrapperspb.String("order-123"))
+	require.NoError(t, err)
+
+	got := &wrapperspb.StringValue{}
+	err = codec.Unmarshal(data, got)
+	require.NoError(t, err)
+	require.Equal(t, "order-123", got.GetValue())
+}
+
+func TestProtoCodec_notProtoMessage(t *testing.T) {
+	type OrderCompleted struct {
+		OrderID string
+	}
+
+	var codec broker.ProtoCodec
+
+	_, err := codec.Marshal(OrderCompleted{OrderID: "order-123"})
+	require.Error(t, err)
+
+	err = codec.Unmarshal([]byte{}, &OrderCompleted{})
+	require.Error(t, err)
+}
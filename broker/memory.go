@@ -0,0 +1,91 @@
+package broker
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// Memory is an in-process [Broker] implementation backed by plain Go
+// function calls. It is primarily intended for tests and examples; it does
+// not persist messages or cross process boundaries.
+type Memory struct {
+	mu          sync.Mutex
+	subscribers map[string][]func(Delivery) error
+}
+
+// NewMemory allocates and returns a new [Memory] broker.
+func NewMemory() *Memory {
+	return &Memory{}
+}
+
+// Connect implements [Broker]. It is a no-op for [Memory].
+func (b *Memory) Connect(ctx context.Context) error {
+	return nil
+}
+
+// Disconnect implements [Broker]. It is a no-op for [Memory].
+func (b *Memory) Disconnect(ctx context.Context) error {
+	return nil
+}
+
+// Publish implements [Broker], calling every handler subscribed to topic
+// synchronously, in registration order. Every handler is called
+// independently of the others' outcome; their errors are combined with
+// [errors.Join].
+func (b *Memory) Publish(ctx context.Context, topic string, payload []byte, header map[string]string) error {
+	b.mu.Lock()
+	handlers := append([]func(Delivery) error(nil), b.subscribers[topic]...)
+	b.mu.Unlock()
+
+	delivery := Delivery{
+		Topic:   topic,
+		Payload: payload,
+		Header:  header,
+	}
+
+	var errs []error
+	for _, handler := range handlers {
+		if err := handler(delivery); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Subscribe implements [Broker].
+func (b *Memory) Subscribe(ctx context.Context, topic string, handler func(Delivery) error) (Subscription, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.subscribers == nil {
+		b.subscribers = make(map[string][]func(Delivery) error)
+	}
+
+	b.subscribers[topic] = append(b.subscribers[topic], handler)
+	idx := len(b.subscribers[topic]) - 1
+
+	return &memorySubscription{broker: b, topic: topic, idx: idx}, nil
+}
+
+type memorySubscription struct {
+	broker *Memory
+	topic  string
+	idx    int
+}
+
+// Unsubscribe implements [Subscription].
+func (s *memorySubscription) Unsubscribe() error {
+	s.broker.mu.Lock()
+	defer s.broker.mu.Unlock()
+
+	handlers := s.broker.subscribers[s.topic]
+	if s.idx < 0 || s.idx >= len(handlers) {
+		return nil
+	}
+
+	handlers[s.idx] = func(Delivery) error { return nil }
+
+	return nil
+}
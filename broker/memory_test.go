@@ -0,0 +1,66 @@
+package broker_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/uudashr/msgmux/broker"
+)
+
+func TestMemory_PublishSubscribe(t *testing.T) {
+	b := broker.NewMemory()
+
+	var got broker.Delivery
+	_, err := b.Subscribe(context.Background(), "orders", func(d broker.Delivery) error {
+		got = d
+		return nil
+	})
+	require.NoError(t, err)
+
+	err = b.Publish(context.Background(), "orders", []byte(`{"order_id":"order-123"}`), map[string]string{"content-type": "application/json"})
+	require.NoError(t, err)
+	require.Equal(t, "orders", got.Topic)
+	require.Equal(t, []byte(`{"order_id":"order-123"}`), got.Payload)
+	require.Equal(t, "application/json", got.Header["content-type"])
+}
+
+func TestMemory_Publish_callsEverySubscriberAndJoinsErrors(t *testing.T) {
+	b := broker.NewMemory()
+
+	errFirst := errors.New("first failed")
+
+	var secondCalled bool
+	_, err := b.Subscribe(context.Background(), "orders", func(d broker.Delivery) error {
+		return errFirst
+	})
+	require.NoError(t, err)
+
+	_, err = b.Subscribe(context.Background(), "orders", func(d broker.Delivery) error {
+		secondCalled = true
+		return nil
+	})
+	require.NoError(t, err)
+
+	err = b.Publish(context.Background(), "orders", []byte("{}"), nil)
+	require.ErrorIs(t, err, errFirst)
+	require.True(t, secondCalled, "second subscriber should still be called after the first returns an error")
+}
+
+func TestMemory_Unsubscribe(t *testing.T) {
+	b := broker.NewMemory()
+
+	called := false
+	sub, err := b.Subscribe(context.Background(), "orders", func(d broker.Delivery) error {
+		called = true
+		return nil
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, sub.Unsubscribe())
+
+	err = b.Publish(context.Background(), "orders", []byte("{}"), nil)
+	require.NoError(t, err)
+	require.False(t, called)
+}
@@ -0,0 +1,95 @@
+// Package brokernats implements a [broker.Broker] backed by NATS core
+// pub/sub. It lives in its own module so the root msgmux module does not
+// have to depend on the NATS client.
+package brokernats
+
+import (
+	"context"
+
+	"github.com/nats-io/nats.go"
+
+	"github.com/uudashr/msgmux/broker"
+)
+
+// Broker adapts a [nats.Conn] to [broker.Broker].
+type Broker struct {
+	url  string
+	opts []nats.Option
+	conn *nats.Conn
+}
+
+// New returns a [Broker] that will dial url with opts on Connect.
+func New(url string, opts ...nats.Option) *Broker {
+	return &Broker{url: url, opts: opts}
+}
+
+// Connect implements [broker.Broker].
+func (b *Broker) Connect(ctx context.Context) error {
+	conn, err := nats.Connect(b.url, b.opts...)
+	if err != nil {
+		return err
+	}
+
+	b.conn = conn
+
+	return nil
+}
+
+// Disconnect implements [broker.Broker].
+func (b *Broker) Disconnect(ctx context.Context) error {
+	if b.conn == nil {
+		return nil
+	}
+
+	b.conn.Close()
+
+	return nil
+}
+
+// Publish implements [broker.Broker]. header, if not empty, is sent as the
+// message's [nats.Msg.Header].
+func (b *Broker) Publish(ctx context.Context, topic string, payload []byte, header map[string]string) error {
+	msg := &nats.Msg{
+		Subject: topic,
+		Data:    payload,
+	}
+
+	if len(header) > 0 {
+		msg.Header = nats.Header{}
+		for k, v := range header {
+			msg.Header.Set(k, v)
+		}
+	}
+
+	return b.conn.PublishMsg(msg)
+}
+
+// Subscribe implements [broker.Broker].
+func (b *Broker) Subscribe(ctx context.Context, topic string, handler func(broker.Delivery) error) (broker.Subscription, error) {
+	sub, err := b.conn.Subscribe(topic, func(msg *nats.Msg) {
+		header := make(map[string]string, len(msg.Header))
+		for k := range msg.Header {
+			header[k] = msg.Header.Get(k)
+		}
+
+		_ = handler(broker.Delivery{
+			Topic:   msg.Subject,
+			Payload: msg.Data,
+			Header:  header,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return natsSubscription{sub: sub}, nil
+}
+
+type natsSubscription struct {
+	sub *nats.Subscription
+}
+
+// Unsubscribe implements [broker.Subscription].
+func (s natsSubscription) Unsubscribe() error {
+	return s.sub.Unsubscribe()
+}
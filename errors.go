@@ -0,0 +1,62 @@
+package msgmux
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+)
+
+// ErrorHandler is notified whenever a dispatched handler returns a non-nil
+// error, after the middleware chain has run. It is typically used to report
+// errors to an external service (e.g. Sentry, Honeybadger, Bugsnag).
+//
+// HandleError must not block the caller for long, since it runs inline with
+// [DispatchMux.DispatchContext].
+type ErrorHandler interface {
+	HandleError(ctx context.Context, msg Message, err error)
+}
+
+// SetErrorHandler registers h as the [ErrorHandler] invoked whenever a
+// dispatched handler returns a non-nil error. The error is still returned to
+// the caller of [DispatchMux.DispatchContext] afterwards.
+func (m *DispatchMux) SetErrorHandler(h ErrorHandler) {
+	m.errHandler = h
+}
+
+// SetRecover enables or disables panic recovery for dispatched handlers.
+// When enabled, a panic raised by a handler (or by a middleware registered
+// via [DispatchMux.Use]) is recovered and converted into a [*PanicError],
+// which is then routed through the registered [ErrorHandler] like any other
+// handler error. Recovery is disabled by default.
+func (m *DispatchMux) SetRecover(enable bool) {
+	m.recover = enable
+}
+
+// PanicError wraps a value recovered from a panicking handler, along with
+// the stack trace captured at the time of the panic.
+type PanicError struct {
+	Value any
+	Stack []byte
+}
+
+// Error implements the error interface.
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("msgmux: handler panicked: %v", e.Value)
+}
+
+// recoverer returns a [HandlerFunc] that wraps next, recovering any panic
+// and converting it into a [*PanicError].
+func recoverer(next HandlerFunc) HandlerFunc {
+	return func(ctx context.Context, msg Message) (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = &PanicError{
+					Value: r,
+					Stack: debug.Stack(),
+				}
+			}
+		}()
+
+		return next(ctx, msg)
+	}
+}
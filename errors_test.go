@@ -0,0 +1,117 @@
+package msgmux_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/uudashr/msgmux"
+)
+
+type recordingErrorHandler struct {
+	msg msgmux.Message
+	err error
+}
+
+func (h *recordingErrorHandler) HandleError(ctx context.Context, msg msgmux.Message, err error) {
+	h.msg = msg
+	h.err = err
+}
+
+// errorHandlerFunc adapts a function to [msgmux.ErrorHandler].
+type errorHandlerFunc func(ctx context.Context, msg msgmux.Message, err error)
+
+func (f errorHandlerFunc) HandleError(ctx context.Context, msg msgmux.Message, err error) {
+	f(ctx, msg, err)
+}
+
+func TestDispatchMux_SetErrorHandler(t *testing.T) {
+	type OrderCompleted struct {
+		OrderID string
+	}
+
+	errFailed := errors.New("failed")
+
+	mux := msgmux.NewDispatchMux()
+
+	var errHandler recordingErrorHandler
+	mux.SetErrorHandler(&errHandler)
+
+	mux.Handle(func(event OrderCompleted) error {
+		return errFailed
+	})
+
+	event := OrderCompleted{OrderID: "order-123"}
+	err := mux.DispatchContext(context.Background(), event)
+	require.ErrorIs(t, err, errFailed)
+	require.Equal(t, event, errHandler.msg)
+	require.ErrorIs(t, errHandler.err, errFailed)
+}
+
+func TestDispatchMux_SetRecover(t *testing.T) {
+	type OrderCompleted struct {
+		OrderID string
+	}
+
+	mux := msgmux.NewDispatchMux()
+	mux.SetRecover(true)
+
+	var errHandler recordingErrorHandler
+	mux.SetErrorHandler(&errHandler)
+
+	mux.Handle(func(event OrderCompleted) error {
+		panic("boom")
+	})
+
+	err := mux.DispatchContext(context.Background(), OrderCompleted{OrderID: "order-123"})
+
+	var panicErr *msgmux.PanicError
+	require.ErrorAs(t, err, &panicErr)
+	require.Equal(t, "boom", panicErr.Value)
+	require.NotEmpty(t, panicErr.Stack)
+	require.ErrorAs(t, errHandler.err, &panicErr)
+}
+
+func TestDispatchMux_SetRecover_middlewarePanic(t *testing.T) {
+	type OrderCompleted struct {
+		OrderID string
+	}
+
+	mux := msgmux.NewDispatchMux()
+	mux.SetRecover(true)
+	mux.Use(func(next msgmux.HandlerFunc) msgmux.HandlerFunc {
+		return func(ctx context.Context, msg msgmux.Message) error {
+			panic("boom from middleware")
+		}
+	})
+
+	var errHandler recordingErrorHandler
+	mux.SetErrorHandler(&errHandler)
+
+	mux.Handle(func(event OrderCompleted) error {
+		return nil
+	})
+
+	err := mux.DispatchContext(context.Background(), OrderCompleted{OrderID: "order-123"})
+
+	var panicErr *msgmux.PanicError
+	require.ErrorAs(t, err, &panicErr)
+	require.Equal(t, "boom from middleware", panicErr.Value)
+	require.ErrorAs(t, errHandler.err, &panicErr)
+}
+
+func TestDispatchMux_SetRecover_disabledByDefault(t *testing.T) {
+	type OrderCompleted struct {
+		OrderID string
+	}
+
+	mux := msgmux.NewDispatchMux()
+	mux.Handle(func(event OrderCompleted) error {
+		panic("boom")
+	})
+
+	require.Panics(t, func() {
+		mux.DispatchContext(context.Background(), OrderCompleted{OrderID: "order-123"})
+	})
+}
@@ -0,0 +1,176 @@
+package msgmux
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// namedMessage is implemented by messages that want to customize the name
+// used by [DispatchMux.HandlePattern] matching, instead of relying on the
+// message struct's type name.
+type namedMessage interface {
+	MessageName() string
+}
+
+// messageName returns msg's name for pattern matching: the result of
+// MessageName, if msg implements [namedMessage], otherwise the struct
+// type's name.
+func messageName(msg Message) string {
+	if nm, ok := msg.(namedMessage); ok {
+		return nm.MessageName()
+	}
+
+	return reflect.TypeOf(msg).Name()
+}
+
+type patternHandlerEntry struct {
+	prefix string
+	fn     MessageHandler
+}
+
+// HandlePattern registers fn as the handler for every message whose name
+// (as reported by [messageName]) starts with prefix, unless a more specific
+// (longer) pattern or an exact [DispatchMux.Handle] registration matches
+// first.
+//
+// fn must accept a [Message] rather than a concrete struct type, since a
+// single pattern can match several concrete message types:
+//
+//	mux.HandlePattern("Order", func(ctx context.Context, msg msgmux.Message) error {
+//		// handles OrderCreated, OrderCompleted, OrderCancelled, ...
+//		return nil
+//	})
+//
+// [DispatchMux.DispatchContext] resolves a message's handler in this order:
+// the exact [DispatchMux.Handle] registration for its type, then the longest
+// matching pattern, then the first matching [DispatchMux.HandleInterface]
+// registration.
+func (m *DispatchMux) HandlePattern(prefix string, fn MessageHandler) {
+	if err := validateInterfaceHandler(fn, anyType); err != nil {
+		panic(err)
+	}
+
+	m.patternHandlers = append(m.patternHandlers, patternHandlerEntry{prefix: prefix, fn: fn})
+}
+
+// matchPattern returns the handler registered via [DispatchMux.HandlePattern]
+// with the longest prefix matching msg's name, if any.
+func (m *DispatchMux) matchPattern(msg Message) (MessageHandler, bool) {
+	if len(m.patternHandlers) == 0 {
+		return nil, false
+	}
+
+	name := messageName(msg)
+
+	var (
+		best    MessageHandler
+		bestLen = -1
+	)
+
+	for _, entry := range m.patternHandlers {
+		if strings.HasPrefix(name, entry.prefix) && len(entry.prefix) > bestLen {
+			best = entry.fn
+			bestLen = len(entry.prefix)
+		}
+	}
+
+	return best, bestLen >= 0
+}
+
+type interfaceHandlerEntry struct {
+	ifaceType reflect.Type
+	fn        MessageHandler
+}
+
+// HandleInterface registers fn as the handler for every message whose
+// concrete type implements iface, unless an exact [DispatchMux.Handle]
+// registration or a [DispatchMux.HandlePattern] match takes precedence.
+//
+// iface must be a nil pointer to the interface type, e.g. (*MyEvent)(nil):
+//
+//	type MyEvent interface {
+//		EventName() string
+//	}
+//
+//	mux.HandleInterface((*MyEvent)(nil), func(ctx context.Context, e MyEvent) error {
+//		// handle any message implementing MyEvent
+//		return nil
+//	})
+//
+// When more than one registered interface matches, the first one registered
+// wins. [DispatchMux.DispatchContext] resolves a message's handler in this
+// order: the exact [DispatchMux.Handle] registration for its type, then the
+// longest matching [DispatchMux.HandlePattern] pattern, then the first
+// matching interface.
+func (m *DispatchMux) HandleInterface(iface any, fn MessageHandler) {
+	ifaceType := reflect.TypeOf(iface)
+	if ifaceType == nil || ifaceType.Kind() != reflect.Ptr || ifaceType.Elem().Kind() != reflect.Interface {
+		panic(fmt.Sprintf("msgmux: iface should be a nil pointer to an interface type, e.g. (*MyEvent)(nil) (got: %T)", iface))
+	}
+
+	ifaceElem := ifaceType.Elem()
+
+	if err := validateInterfaceHandler(fn, ifaceElem); err != nil {
+		panic(err)
+	}
+
+	m.interfaceHandlers = append(m.interfaceHandlers, interfaceHandlerEntry{ifaceType: ifaceElem, fn: fn})
+}
+
+// matchInterface returns the first handler registered via
+// [DispatchMux.HandleInterface] whose interface eventType implements.
+func (m *DispatchMux) matchInterface(eventType reflect.Type) (MessageHandler, bool) {
+	for _, entry := range m.interfaceHandlers {
+		if eventType.Implements(entry.ifaceType) {
+			return entry.fn, true
+		}
+	}
+
+	return nil, false
+}
+
+// anyType is the interface{}/any type, used as the expected parameter type
+// for [DispatchMux.HandlePattern] handlers, which must accept any concrete
+// message type.
+var anyType = reflect.TypeFor[any]()
+
+// validateInterfaceHandler validates fn the same way [validateHandler] does,
+// except its message parameter must be an interface type with the same
+// method set as ifaceType (see [sameInterface]) instead of a struct.
+func validateInterfaceHandler(fn MessageHandler, ifaceType reflect.Type) error {
+	fnType := reflect.TypeOf(fn)
+	if fnType == nil || fnType.Kind() != reflect.Func {
+		return fmt.Errorf("msgmux: fn MessageHandler is not a function (got: %T)", fn)
+	}
+
+	switch fnType.NumIn() {
+	case 1:
+		if !sameInterface(fnType.In(0), ifaceType) {
+			return fmt.Errorf("msgmux: fn MessageHandler input parameter should be %v (got: %v)", ifaceType, fnType.In(0))
+		}
+	case 2:
+		if err := validateContextParam(fnType.In(0)); err != nil {
+			return err
+		}
+
+		if !sameInterface(fnType.In(1), ifaceType) {
+			return fmt.Errorf("msgmux: fn MessageHandler 2nd input parameter should be %v (got: %v)", ifaceType, fnType.In(1))
+		}
+	default:
+		return fmt.Errorf("msgmux: fn MessageHandler should have 1 or 2 input parameters (got: %d)", fnType.NumIn())
+	}
+
+	return validateErrorOutput(fnType)
+}
+
+// sameInterface reports whether a and b are both interface types with the
+// same method set, regardless of their declared names (e.g. [Message] and
+// the predeclared any are the same empty interface).
+func sameInterface(a, b reflect.Type) bool {
+	if a.Kind() != reflect.Interface || b.Kind() != reflect.Interface {
+		return false
+	}
+
+	return a.Implements(b) && b.Implements(a)
+}
@@ -0,0 +1,115 @@
+package msgmux_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/uudashr/msgmux"
+)
+
+type orderEvent interface {
+	OrderID() string
+}
+
+type OrderCreated struct {
+	ID string
+}
+
+func (e OrderCreated) OrderID() string { return e.ID }
+
+type OrderCompleted struct {
+	ID string
+}
+
+func (e OrderCompleted) OrderID() string { return e.ID }
+
+type OrderCancelled struct {
+	ID string
+}
+
+func (e OrderCancelled) OrderID() string { return e.ID }
+
+func TestDispatchMux_HandleInterface(t *testing.T) {
+	mux := msgmux.NewDispatchMux()
+
+	var got string
+	mux.HandleInterface((*orderEvent)(nil), func(e orderEvent) error {
+		got = e.OrderID()
+		return nil
+	})
+
+	err := mux.Dispatch(OrderCreated{ID: "order-123"})
+	require.NoError(t, err)
+	require.Equal(t, "order-123", got)
+}
+
+func TestDispatchMux_HandlePattern(t *testing.T) {
+	mux := msgmux.NewDispatchMux()
+
+	var got []string
+	mux.HandlePattern("Order", func(ctx context.Context, msg msgmux.Message) error {
+		got = append(got, "Order")
+		return nil
+	})
+
+	err := mux.Dispatch(OrderCreated{ID: "order-123"})
+	require.NoError(t, err)
+	err = mux.Dispatch(OrderCancelled{ID: "order-123"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"Order", "Order"}, got)
+}
+
+func TestDispatchMux_HandlePattern_longestPrefixWins(t *testing.T) {
+	mux := msgmux.NewDispatchMux()
+
+	var got []string
+	mux.HandlePattern("Order", func(msg msgmux.Message) error {
+		got = append(got, "Order")
+		return nil
+	})
+	mux.HandlePattern("OrderCreated", func(msg msgmux.Message) error {
+		got = append(got, "OrderCreated")
+		return nil
+	})
+
+	err := mux.Dispatch(OrderCreated{ID: "order-123"})
+	require.NoError(t, err)
+	err = mux.Dispatch(OrderCancelled{ID: "order-123"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"OrderCreated", "Order"}, got)
+}
+
+func TestDispatchMux_resolutionOrder(t *testing.T) {
+	mux := msgmux.NewDispatchMux()
+
+	var got string
+	mux.HandlePattern("Order", func(msg msgmux.Message) error {
+		got = "pattern"
+		return nil
+	})
+	mux.HandleInterface((*orderEvent)(nil), func(e orderEvent) error {
+		got = "interface"
+		return nil
+	})
+	mux.Handle(func(e OrderCreated) error {
+		got = "exact"
+		return nil
+	})
+
+	err := mux.Dispatch(OrderCreated{ID: "order-123"})
+	require.NoError(t, err)
+	require.Equal(t, "exact", got)
+
+	err = mux.Dispatch(OrderCompleted{ID: "order-123"})
+	require.NoError(t, err)
+	require.Equal(t, "pattern", got)
+}
+
+func TestDispatchMux_HandleInterface_invalidIface(t *testing.T) {
+	mux := msgmux.NewDispatchMux()
+
+	require.Panics(t, func() {
+		mux.HandleInterface(OrderCreated{}, func(e orderEvent) error { return nil })
+	})
+}
@@ -0,0 +1,80 @@
+package msgmux
+
+import (
+	"context"
+	"reflect"
+)
+
+// HandlerFunc is the normalized form of a [MessageHandler], used by
+// [Middleware] to wrap handlers regardless of their original signature.
+type HandlerFunc func(ctx context.Context, msg Message) error
+
+// Middleware wraps a [HandlerFunc], returning a new [HandlerFunc] that can
+// add cross-cutting behavior (logging, tracing, metrics, retry, validation)
+// before and/or after calling next.
+//
+// Example:
+//
+//	func Logging(next msgmux.HandlerFunc) msgmux.HandlerFunc {
+//		return func(ctx context.Context, msg msgmux.Message) error {
+//			log.Printf("dispatching %T", msg)
+//			return next(ctx, msg)
+//		}
+//	}
+type Middleware func(next HandlerFunc) HandlerFunc
+
+// Use appends mw to the chain of middlewares applied to every dispatched
+// message. Middlewares run in registration order, outermost first, wrapping
+// around any middlewares registered on a [Group] for the matching message
+// type.
+func (m *DispatchMux) Use(mw ...Middleware) {
+	m.middlewares = append(m.middlewares, mw...)
+}
+
+func chain(h HandlerFunc, mws []Middleware) HandlerFunc {
+	for i := len(mws) - 1; i >= 0; i-- {
+		h = mws[i](h)
+	}
+
+	return h
+}
+
+// Group scopes a set of middlewares to the handlers registered through it,
+// on top of any mux-wide middlewares registered via [DispatchMux.Use].
+//
+// Example:
+//
+//	g := mux.Group()
+//	g.Use(RequireAuth)
+//	g.Handle(func(ctx context.Context, e CancelOrder) error {
+//		// handle the event
+//		return nil
+//	})
+type Group struct {
+	mux         *DispatchMux
+	middlewares []Middleware
+}
+
+// Group returns a new [Group] bound to m.
+func (m *DispatchMux) Group() *Group {
+	return &Group{mux: m}
+}
+
+// Use appends mw to the group's middleware chain.
+func (g *Group) Use(mw ...Middleware) {
+	g.middlewares = append(g.middlewares, mw...)
+}
+
+// Handle registers fn on the underlying [DispatchMux] and scopes the
+// group's middlewares to fn's message type.
+//
+// See [DispatchMux.Handle] for the registration rules that apply to fn.
+func (g *Group) Handle(fn MessageHandler) {
+	g.mux.Handle(fn)
+
+	msgType := handlerMessageType(fn)
+	if g.mux.groupMiddlewares == nil {
+		g.mux.groupMiddlewares = make(map[reflect.Type][]Middleware)
+	}
+	g.mux.groupMiddlewares[msgType] = append(g.mux.groupMiddlewares[msgType], g.middlewares...)
+}
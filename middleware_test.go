@@ -0,0 +1,136 @@
+package msgmux_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/uudashr/msgmux"
+)
+
+func TestDispatchMux_Use(t *testing.T) {
+	type OrderCompleted struct {
+		OrderID string
+	}
+
+	var order []string
+
+	tagMiddleware := func(tag string) msgmux.Middleware {
+		return func(next msgmux.HandlerFunc) msgmux.HandlerFunc {
+			return func(ctx context.Context, msg msgmux.Message) error {
+				order = append(order, tag+":before")
+				err := next(ctx, msg)
+				order = append(order, tag+":after")
+				return err
+			}
+		}
+	}
+
+	mux := msgmux.NewDispatchMux()
+	mux.Use(tagMiddleware("outer"), tagMiddleware("inner"))
+	mux.Handle(func(ctx context.Context, event OrderCompleted) error {
+		order = append(order, "handler")
+		return nil
+	})
+
+	err := mux.DispatchContext(context.Background(), OrderCompleted{OrderID: "order-123"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"outer:before", "inner:before", "handler", "inner:after", "outer:after"}, order)
+}
+
+func TestDispatchMux_Use_shortCircuit(t *testing.T) {
+	type OrderCompleted struct {
+		OrderID string
+	}
+
+	errBlocked := errors.New("blocked")
+
+	handlerCalled := false
+
+	mux := msgmux.NewDispatchMux()
+	mux.Use(func(next msgmux.HandlerFunc) msgmux.HandlerFunc {
+		return func(ctx context.Context, msg msgmux.Message) error {
+			return errBlocked
+		}
+	})
+	mux.Handle(func(event OrderCompleted) error {
+		handlerCalled = true
+		return nil
+	})
+
+	err := mux.DispatchContext(context.Background(), OrderCompleted{OrderID: "order-123"})
+	require.ErrorIs(t, err, errBlocked)
+	require.False(t, handlerCalled)
+}
+
+type ctxKey struct{}
+
+func TestDispatchMux_Use_contextPropagation(t *testing.T) {
+	type OrderCompleted struct {
+		OrderID string
+	}
+
+	mux := msgmux.NewDispatchMux()
+	mux.Use(func(next msgmux.HandlerFunc) msgmux.HandlerFunc {
+		return func(ctx context.Context, msg msgmux.Message) error {
+			ctx = context.WithValue(ctx, ctxKey{}, "value")
+			return next(ctx, msg)
+		}
+	})
+
+	var gotValue any
+	mux.Handle(func(ctx context.Context, event OrderCompleted) error {
+		gotValue = ctx.Value(ctxKey{})
+		return nil
+	})
+
+	err := mux.DispatchContext(context.Background(), OrderCompleted{OrderID: "order-123"})
+	require.NoError(t, err)
+	require.Equal(t, "value", gotValue)
+}
+
+func TestGroup_Handle(t *testing.T) {
+	type OrderCompleted struct {
+		OrderID string
+	}
+
+	type OrderCancelled struct {
+		OrderID string
+	}
+
+	var order []string
+
+	tagMiddleware := func(tag string) msgmux.Middleware {
+		return func(next msgmux.HandlerFunc) msgmux.HandlerFunc {
+			return func(ctx context.Context, msg msgmux.Message) error {
+				order = append(order, tag)
+				return next(ctx, msg)
+			}
+		}
+	}
+
+	mux := msgmux.NewDispatchMux()
+	mux.Use(tagMiddleware("mux"))
+
+	g := mux.Group()
+	g.Use(tagMiddleware("group"))
+	g.Handle(func(event OrderCompleted) error {
+		order = append(order, "handler")
+		return nil
+	})
+
+	mux.Handle(func(event OrderCancelled) error {
+		order = append(order, "handler")
+		return nil
+	})
+
+	err := mux.DispatchContext(context.Background(), OrderCompleted{OrderID: "order-123"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"mux", "group", "handler"}, order)
+
+	order = nil
+	err = mux.DispatchContext(context.Background(), OrderCancelled{OrderID: "order-123"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"mux", "handler"}, order)
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"sync"
+	"time"
 )
 
 // Message represents a specific message. It must be a struct type.
@@ -43,7 +45,22 @@ type MessageHandler any
 
 // DispatchMux is a message multiplexer.
 type DispatchMux struct {
-	handlers map[reflect.Type]MessageHandler
+	handlers         map[reflect.Type]MessageHandler
+	middlewares      []Middleware
+	groupMiddlewares map[reflect.Type][]Middleware
+	errHandler       ErrorHandler
+	recover          bool
+
+	mu               sync.Mutex
+	subscribers      map[reflect.Type][]subscriberEntry
+	nextSubscriberID uint64
+
+	inbox           Inbox
+	inboxCapacity   int
+	shutdownTimeout time.Duration
+
+	patternHandlers   []patternHandlerEntry
+	interfaceHandlers []interfaceHandlerEntry
 }
 
 // NewDispatchMux allocates and returns a new [DispatchMux].
@@ -64,22 +81,28 @@ func (m *DispatchMux) Handle(fn MessageHandler) {
 		m.handlers = make(map[reflect.Type]MessageHandler)
 	}
 
+	msgType := handlerMessageType(fn)
+
+	if _, reg := m.handlers[msgType]; reg {
+		panic(fmt.Sprintf("msgmux: handler for message %v already registered", msgType.Name()))
+	}
+
+	m.handlers[msgType] = fn
+}
+
+// handlerMessageType returns the [Message] type a [MessageHandler] fn is
+// registered for. fn must already be a valid handler, i.e. it must have
+// passed [validateHandler].
+func handlerMessageType(fn MessageHandler) reflect.Type {
 	fnType := reflect.TypeOf(fn)
-	var msgType reflect.Type
 	switch fnType.NumIn() {
 	case 1:
-		msgType = fnType.In(0)
+		return fnType.In(0)
 	case 2:
-		msgType = fnType.In(1)
+		return fnType.In(1)
 	default:
 		panic(fmt.Sprintf("msgmux: invalid handler function signature (got: %v)", fnType))
 	}
-
-	if _, reg := m.handlers[msgType]; reg {
-		panic(fmt.Sprintf("msgmux: handler for message %v already registered", msgType.Name()))
-	}
-
-	m.handlers[msgType] = fn
 }
 
 // DispatchContext dispatches the message.
@@ -91,16 +114,47 @@ func (m *DispatchMux) DispatchContext(ctx context.Context, msg Message) error {
 		return fmt.Errorf("msgmux: msg should be a struct (got: %v)", eventType.Kind())
 	}
 
-	if m.handlers == nil {
-		return nil
-	}
-
 	handler, reg := m.handlers[eventType]
+	if !reg {
+		handler, reg = m.matchPattern(msg)
+	}
+	if !reg {
+		handler, reg = m.matchInterface(eventType)
+	}
 	if !reg {
 		return fmt.Errorf("msgmux: no handler registered for message %v", eventType.Name())
 	}
 
-	return invokeHandler(ctx, handler, msg)
+	return m.runHandler(ctx, handler, eventType, msg)
+}
+
+// runHandler invokes handler for msg through the mux-wide and group
+// middleware chain for eventType, applying panic recovery
+// ([DispatchMux.SetRecover]) and error reporting
+// ([DispatchMux.SetErrorHandler]) the same way regardless of whether msg
+// arrived through [DispatchMux.DispatchContext] or
+// [DispatchMux.DispatchAllContext].
+func (m *DispatchMux) runHandler(ctx context.Context, handler MessageHandler, eventType reflect.Type, msg Message) error {
+	final := HandlerFunc(func(ctx context.Context, msg Message) error {
+		return invokeHandler(ctx, handler, msg)
+	})
+
+	groupMws := m.groupMiddlewares[eventType]
+	mws := make([]Middleware, 0, len(m.middlewares)+len(groupMws))
+	mws = append(mws, m.middlewares...)
+	mws = append(mws, groupMws...)
+
+	h := chain(final, mws)
+	if m.recover {
+		h = recoverer(h)
+	}
+
+	err := h(ctx, msg)
+	if err != nil && m.errHandler != nil {
+		m.errHandler.HandleError(ctx, msg, err)
+	}
+
+	return err
 }
 
 // Dispatch dispatches the message.
@@ -145,13 +199,8 @@ func validateHandler(fn MessageHandler) error {
 			return fmt.Errorf("msgmux: fn MessageHandler input parameter should be a struct (got: %v)", fnType.In(0).Kind())
 		}
 	case 2:
-		if fnType.In(0).Kind() != reflect.Interface {
-			// expect context.Context interface, but got non-interface
-			return fmt.Errorf("msgmux: fn MessageHandler 1st input parameter should be an context.Context (got: %v)", fnType.In(0).Kind())
-		}
-
-		if !fnType.In(0).Implements(reflect.TypeFor[context.Context]()) {
-			return fmt.Errorf("msgmux: fn MessageHandler 1st input parameter should be context.Context (got: %v)", fnType.In(0).Kind())
+		if err := validateContextParam(fnType.In(0)); err != nil {
+			return err
 		}
 
 		if fnType.In(1).Kind() != reflect.Struct {
@@ -161,6 +210,27 @@ func validateHandler(fn MessageHandler) error {
 		return fmt.Errorf("msgmux: fn MessageHandler should have 1 or 2 input parameters (got: %d)", fnType.NumIn())
 	}
 
+	return validateErrorOutput(fnType)
+}
+
+// validateContextParam reports whether t is [context.Context], the 1st
+// parameter type expected by a 2-argument [MessageHandler].
+func validateContextParam(t reflect.Type) error {
+	if t.Kind() != reflect.Interface {
+		// expect context.Context interface, but got non-interface
+		return fmt.Errorf("msgmux: fn MessageHandler 1st input parameter should be an context.Context (got: %v)", t.Kind())
+	}
+
+	if !t.Implements(reflect.TypeFor[context.Context]()) {
+		return fmt.Errorf("msgmux: fn MessageHandler 1st input parameter should be context.Context (got: %v)", t.Kind())
+	}
+
+	return nil
+}
+
+// validateErrorOutput reports whether fnType's single output parameter is
+// the error interface, as required by every handler function shape.
+func validateErrorOutput(fnType reflect.Type) error {
 	if fnType.NumOut() != 1 {
 		return fmt.Errorf("msgmux: fn MessageHandler should have 1 output parameter (got: %d)", fnType.NumOut())
 	}
@@ -0,0 +1,170 @@
+package msgmux
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// SubscribeOptions controls how [DispatchMux.DispatchAllContext] invokes the
+// subscribers registered for a message type.
+type SubscribeOptions struct {
+	// Concurrent, when true, invokes all subscribers for the message
+	// concurrently instead of sequentially.
+	Concurrent bool
+
+	// MaxConcurrency bounds the number of subscribers invoked at once when
+	// Concurrent is true. Zero or negative means unbounded.
+	MaxConcurrency int
+}
+
+type subscriberEntry struct {
+	id uint64
+	fn MessageHandler
+}
+
+// Subscribe registers fn as one of potentially many handlers for its message
+// type, unlike [DispatchMux.Handle] which allows only one. Subscribers for a
+// message type are invoked by [DispatchMux.DispatchAllContext], in
+// registration order unless [SubscribeOptions] Concurrent is set.
+//
+// The fn need to be valid [MessageHandler], otherwise it will panic.
+//
+// Subscribe returns an unsubscribe function that removes fn from the
+// message type's subscriber list. It is safe to call Subscribe and
+// unsubscribe concurrently, and safe to call unsubscribe more than once.
+func (m *DispatchMux) Subscribe(fn MessageHandler) (unsubscribe func()) {
+	if err := validateHandler(fn); err != nil {
+		panic(err)
+	}
+
+	msgType := handlerMessageType(fn)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.subscribers == nil {
+		m.subscribers = make(map[reflect.Type][]subscriberEntry)
+	}
+
+	m.nextSubscriberID++
+	id := m.nextSubscriberID
+	m.subscribers[msgType] = append(m.subscribers[msgType], subscriberEntry{id: id, fn: fn})
+
+	return func() {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+
+		entries := m.subscribers[msgType]
+		for i, entry := range entries {
+			if entry.id == id {
+				m.subscribers[msgType] = append(entries[:i], entries[i+1:]...)
+				break
+			}
+		}
+	}
+}
+
+// DispatchAllContext dispatches msg to every subscriber registered for its
+// message type via [DispatchMux.Subscribe]. It is independent of
+// [DispatchMux.Handle]/[DispatchMux.DispatchContext], which keep their
+// single-handler-per-type semantics unchanged.
+//
+// Each subscriber runs through the same mux-wide and [DispatchMux.Group]
+// middleware chain, panic recovery ([DispatchMux.SetRecover]), and error
+// reporting ([DispatchMux.SetErrorHandler]) as [DispatchMux.DispatchContext]:
+// a middleware registered via [DispatchMux.Use] sees every message dispatched
+// through either path, a panic is recovered into a [*PanicError] when
+// recovery is enabled (this also applies inside the goroutines used by
+// Concurrent mode, so a panicking subscriber no longer crashes the process),
+// and every subscriber's error, individually, is reported to the registered
+// [ErrorHandler].
+//
+// By default subscribers are invoked sequentially, in registration order,
+// and their errors are combined with [errors.Join]. Passing a
+// [SubscribeOptions] with Concurrent set to true invokes subscribers
+// concurrently instead, bounded by MaxConcurrency; in that mode the order in
+// which subscribers run (and therefore the order of joined errors) is not
+// guaranteed.
+//
+// If no subscriber is registered for msg's type, DispatchAllContext returns
+// nil.
+func (m *DispatchMux) DispatchAllContext(ctx context.Context, msg Message, opts ...SubscribeOptions) error {
+	eventType := reflect.TypeOf(msg)
+	if eventType.Kind() != reflect.Struct {
+		return fmt.Errorf("msgmux: msg should be a struct (got: %v)", eventType.Kind())
+	}
+
+	m.mu.Lock()
+	entries := append([]subscriberEntry(nil), m.subscribers[eventType]...)
+	m.mu.Unlock()
+
+	if len(entries) == 0 {
+		return nil
+	}
+
+	var opt SubscribeOptions
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+
+	if !opt.Concurrent {
+		var errs []error
+		for _, entry := range entries {
+			if err := m.invokeSubscriber(ctx, entry.fn, msg); err != nil {
+				errs = append(errs, err)
+			}
+		}
+
+		return errors.Join(errs...)
+	}
+
+	maxConcurrency := opt.MaxConcurrency
+	if maxConcurrency <= 0 || maxConcurrency > len(entries) {
+		maxConcurrency = len(entries)
+	}
+
+	var (
+		wg   sync.WaitGroup
+		sem  = make(chan struct{}, maxConcurrency)
+		mu   sync.Mutex
+		errs []error
+	)
+
+	for _, entry := range entries {
+		wg.Add(1)
+		sem <- struct{}{}
+
+		go func(fn MessageHandler) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			if err := m.invokeSubscriber(ctx, fn, msg); err != nil {
+				mu.Lock()
+				errs = append(errs, err)
+				mu.Unlock()
+			}
+		}(entry.fn)
+	}
+
+	wg.Wait()
+
+	return errors.Join(errs...)
+}
+
+// invokeSubscriber calls fn for msg through [DispatchMux.runHandler], the
+// same middleware/recover/error-handling path [DispatchMux.DispatchContext]
+// uses.
+func (m *DispatchMux) invokeSubscriber(ctx context.Context, fn MessageHandler, msg Message) error {
+	return m.runHandler(ctx, fn, reflect.TypeOf(msg), msg)
+}
+
+// DispatchAll dispatches msg to every subscriber registered for its message
+// type.
+//
+// A shorthand of [DispatchMux.DispatchAllContext] with [context.Background].
+func (m *DispatchMux) DispatchAll(msg Message, opts ...SubscribeOptions) error {
+	return m.DispatchAllContext(context.Background(), msg, opts...)
+}
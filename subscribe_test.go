@@ -0,0 +1,211 @@
+package msgmux_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/uudashr/msgmux"
+)
+
+func TestDispatchMux_Subscribe(t *testing.T) {
+	type OrderCompleted struct {
+		OrderID string
+	}
+
+	var got []string
+
+	mux := msgmux.NewDispatchMux()
+	mux.Subscribe(func(event OrderCompleted) error {
+		got = append(got, "first")
+		return nil
+	})
+	mux.Subscribe(func(event OrderCompleted) error {
+		got = append(got, "second")
+		return nil
+	})
+
+	err := mux.DispatchAllContext(context.Background(), OrderCompleted{OrderID: "order-123"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"first", "second"}, got)
+}
+
+func TestDispatchMux_Subscribe_noSubscribers(t *testing.T) {
+	type OrderCompleted struct {
+		OrderID string
+	}
+
+	mux := msgmux.NewDispatchMux()
+	err := mux.DispatchAllContext(context.Background(), OrderCompleted{OrderID: "order-123"})
+	require.NoError(t, err)
+}
+
+func TestDispatchMux_Subscribe_aggregatesErrors(t *testing.T) {
+	type OrderCompleted struct {
+		OrderID string
+	}
+
+	errFirst := errors.New("first failed")
+	errSecond := errors.New("second failed")
+
+	mux := msgmux.NewDispatchMux()
+	mux.Subscribe(func(event OrderCompleted) error {
+		return errFirst
+	})
+	mux.Subscribe(func(event OrderCompleted) error {
+		return errSecond
+	})
+
+	err := mux.DispatchAllContext(context.Background(), OrderCompleted{OrderID: "order-123"})
+	require.ErrorIs(t, err, errFirst)
+	require.ErrorIs(t, err, errSecond)
+}
+
+func TestDispatchMux_Subscribe_unsubscribe(t *testing.T) {
+	type OrderCompleted struct {
+		OrderID string
+	}
+
+	called := false
+
+	mux := msgmux.NewDispatchMux()
+	unsubscribe := mux.Subscribe(func(event OrderCompleted) error {
+		called = true
+		return nil
+	})
+	unsubscribe()
+
+	err := mux.DispatchAllContext(context.Background(), OrderCompleted{OrderID: "order-123"})
+	require.NoError(t, err)
+	require.False(t, called)
+}
+
+func TestDispatchMux_DispatchAllContext_recoversPanic(t *testing.T) {
+	type OrderCompleted struct {
+		OrderID string
+	}
+
+	mux := msgmux.NewDispatchMux()
+	mux.SetRecover(true)
+
+	var errHandler recordingErrorHandler
+	mux.SetErrorHandler(&errHandler)
+
+	mux.Subscribe(func(event OrderCompleted) error {
+		panic("boom")
+	})
+
+	err := mux.DispatchAllContext(context.Background(), OrderCompleted{OrderID: "order-123"})
+
+	var panicErr *msgmux.PanicError
+	require.ErrorAs(t, err, &panicErr)
+	require.Equal(t, "boom", panicErr.Value)
+	require.ErrorAs(t, errHandler.err, &panicErr)
+}
+
+func TestDispatchMux_DispatchAllContext_concurrent_recoversPanic(t *testing.T) {
+	type OrderCompleted struct {
+		OrderID string
+	}
+
+	mux := msgmux.NewDispatchMux()
+	mux.SetRecover(true)
+	mux.Subscribe(func(event OrderCompleted) error {
+		panic("boom")
+	})
+	mux.Subscribe(func(event OrderCompleted) error {
+		return nil
+	})
+
+	err := mux.DispatchAllContext(context.Background(), OrderCompleted{OrderID: "order-123"}, msgmux.SubscribeOptions{
+		Concurrent: true,
+	})
+
+	var panicErr *msgmux.PanicError
+	require.ErrorAs(t, err, &panicErr)
+}
+
+func TestDispatchMux_DispatchAllContext_reportsEachSubscriberError(t *testing.T) {
+	type OrderCompleted struct {
+		OrderID string
+	}
+
+	errFailed := errors.New("failed")
+
+	var (
+		mu   sync.Mutex
+		seen []error
+	)
+
+	mux := msgmux.NewDispatchMux()
+	mux.SetErrorHandler(errorHandlerFunc(func(ctx context.Context, msg msgmux.Message, err error) {
+		mu.Lock()
+		seen = append(seen, err)
+		mu.Unlock()
+	}))
+	mux.Subscribe(func(event OrderCompleted) error {
+		return errFailed
+	})
+	mux.Subscribe(func(event OrderCompleted) error {
+		return nil
+	})
+
+	err := mux.DispatchAllContext(context.Background(), OrderCompleted{OrderID: "order-123"})
+	require.ErrorIs(t, err, errFailed)
+	require.Len(t, seen, 1)
+	require.ErrorIs(t, seen[0], errFailed)
+}
+
+func TestDispatchMux_DispatchAllContext_runsThroughMiddleware(t *testing.T) {
+	type OrderCompleted struct {
+		OrderID string
+	}
+
+	var order []string
+
+	mux := msgmux.NewDispatchMux()
+	mux.Use(func(next msgmux.HandlerFunc) msgmux.HandlerFunc {
+		return func(ctx context.Context, msg msgmux.Message) error {
+			order = append(order, "middleware")
+			return next(ctx, msg)
+		}
+	})
+	mux.Subscribe(func(event OrderCompleted) error {
+		order = append(order, "subscriber")
+		return nil
+	})
+
+	err := mux.DispatchAllContext(context.Background(), OrderCompleted{OrderID: "order-123"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"middleware", "subscriber"}, order)
+}
+
+func TestDispatchMux_DispatchAllContext_concurrent(t *testing.T) {
+	type OrderCompleted struct {
+		OrderID string
+	}
+
+	var (
+		mu    sync.Mutex
+		count int
+	)
+
+	mux := msgmux.NewDispatchMux()
+	for i := 0; i < 5; i++ {
+		mux.Subscribe(func(event OrderCompleted) error {
+			mu.Lock()
+			count++
+			mu.Unlock()
+			return nil
+		})
+	}
+
+	err := mux.DispatchAllContext(context.Background(), OrderCompleted{OrderID: "order-123"}, msgmux.SubscribeOptions{
+		Concurrent:     true,
+		MaxConcurrency: 2,
+	})
+	require.NoError(t, err)
+	require.Equal(t, 5, count)
+}
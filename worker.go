@@ -0,0 +1,157 @@
+package msgmux
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Inbox is a queue of pending [Message] values consumed by
+// [DispatchMux.RunWorkers]. The default, in-memory implementation is
+// replaced by calling [DispatchMux.SetInbox], e.g. with a durable
+// implementation backed by BoltDB, BadgerDB or Redis.
+type Inbox interface {
+	// Enqueue adds msg to the queue, blocking for backpressure until space
+	// is available or ctx is done.
+	Enqueue(ctx context.Context, msg Message) error
+
+	// Dequeue removes and returns the next msg, blocking until one is
+	// available or ctx is done.
+	Dequeue(ctx context.Context) (Message, error)
+}
+
+// memoryInbox is the default, non-durable [Inbox] implementation.
+type memoryInbox struct {
+	ch chan Message
+}
+
+func newMemoryInbox(capacity int) *memoryInbox {
+	return &memoryInbox{ch: make(chan Message, capacity)}
+}
+
+func (b *memoryInbox) Enqueue(ctx context.Context, msg Message) error {
+	select {
+	case b.ch <- msg:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (b *memoryInbox) Dequeue(ctx context.Context) (Message, error) {
+	select {
+	case msg := <-b.ch:
+		return msg, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// SetInbox replaces the default in-memory [Inbox] used by
+// [DispatchMux.Enqueue] and [DispatchMux.RunWorkers] with inbox. It must be
+// called before the first call to either.
+func (m *DispatchMux) SetInbox(inbox Inbox) {
+	m.inbox = inbox
+}
+
+// SetInboxCapacity sets the buffer capacity of the default in-memory
+// [Inbox]. It has no effect once [DispatchMux.SetInbox] has been called
+// with a custom [Inbox].
+func (m *DispatchMux) SetInboxCapacity(capacity int) {
+	m.inboxCapacity = capacity
+}
+
+// SetShutdownTimeout bounds how long [DispatchMux.RunWorkers] waits for
+// in-flight messages to finish processing once its context is done. The
+// zero value (the default) waits indefinitely.
+func (m *DispatchMux) SetShutdownTimeout(d time.Duration) {
+	m.shutdownTimeout = d
+}
+
+func (m *DispatchMux) defaultInbox() Inbox {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.inbox == nil {
+		capacity := m.inboxCapacity
+		if capacity <= 0 {
+			capacity = 64
+		}
+
+		m.inbox = newMemoryInbox(capacity)
+	}
+
+	return m.inbox
+}
+
+// EnqueueContext pushes msg onto the mux's [Inbox], to be picked up by a
+// worker started with [DispatchMux.RunWorkers]. It blocks for backpressure
+// until the inbox accepts msg or ctx is done.
+func (m *DispatchMux) EnqueueContext(ctx context.Context, msg Message) error {
+	return m.defaultInbox().Enqueue(ctx, msg)
+}
+
+// Enqueue pushes msg onto the mux's [Inbox].
+//
+// A shorthand of [DispatchMux.EnqueueContext] with [context.Background].
+func (m *DispatchMux) Enqueue(msg Message) error {
+	return m.EnqueueContext(context.Background(), msg)
+}
+
+// RunWorkers starts n goroutines that drain the mux's [Inbox] and dispatch
+// each message through [DispatchMux.DispatchContext], reporting errors via
+// the registered [ErrorHandler] as usual.
+//
+// RunWorkers blocks until ctx is done. Once ctx is done, no further
+// messages are dequeued, but messages already being handled are allowed to
+// finish, up to the duration set by [DispatchMux.SetShutdownTimeout].
+func (m *DispatchMux) RunWorkers(ctx context.Context, n int) error {
+	if n <= 0 {
+		return fmt.Errorf("msgmux: n should be greater than 0 (got: %d)", n)
+	}
+
+	inbox := m.defaultInbox()
+
+	var wg sync.WaitGroup
+	wg.Add(n)
+
+	for i := 0; i < n; i++ {
+		go func() {
+			defer wg.Done()
+
+			for {
+				if ctx.Err() != nil {
+					return
+				}
+
+				msg, err := inbox.Dequeue(ctx)
+				if err != nil {
+					return
+				}
+
+				m.DispatchContext(context.Background(), msg)
+			}
+		}()
+	}
+
+	<-ctx.Done()
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	if m.shutdownTimeout <= 0 {
+		<-done
+		return nil
+	}
+
+	select {
+	case <-done:
+	case <-time.After(m.shutdownTimeout):
+	}
+
+	return nil
+}
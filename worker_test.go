@@ -0,0 +1,95 @@
+package msgmux_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/uudashr/msgmux"
+)
+
+func TestDispatchMux_RunWorkers(t *testing.T) {
+	type OrderCompleted struct {
+		OrderID string
+	}
+
+	var (
+		mu  sync.Mutex
+		got []string
+	)
+
+	mux := msgmux.NewDispatchMux()
+	mux.Handle(func(event OrderCompleted) error {
+		mu.Lock()
+		got = append(got, event.OrderID)
+		mu.Unlock()
+		return nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		mux.RunWorkers(ctx, 2)
+	}()
+
+	require.NoError(t, mux.Enqueue(OrderCompleted{OrderID: "order-1"}))
+	require.NoError(t, mux.Enqueue(OrderCompleted{OrderID: "order-2"}))
+
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(got) == 2
+	}, time.Second, time.Millisecond)
+
+	cancel()
+	wg.Wait()
+}
+
+func TestDispatchMux_RunWorkers_invalidN(t *testing.T) {
+	mux := msgmux.NewDispatchMux()
+	err := mux.RunWorkers(context.Background(), 0)
+	require.Error(t, err)
+}
+
+func TestDispatchMux_RunWorkers_shutdownTimeout(t *testing.T) {
+	type OrderCompleted struct {
+		OrderID string
+	}
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+
+	mux := msgmux.NewDispatchMux()
+	mux.SetShutdownTimeout(50 * time.Millisecond)
+	mux.Handle(func(event OrderCompleted) error {
+		close(started)
+		<-release
+		return nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	require.NoError(t, mux.Enqueue(OrderCompleted{OrderID: "order-1"}))
+
+	done := make(chan struct{})
+	go func() {
+		mux.RunWorkers(ctx, 1)
+		close(done)
+	}()
+
+	<-started
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("RunWorkers did not return after shutdown timeout elapsed")
+	}
+
+	close(release)
+}